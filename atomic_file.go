@@ -0,0 +1,63 @@
+package id_generator
+
+import (
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"runtime"
+)
+
+// writeFileAtomic writes data to path without ever leaving a truncated file
+// behind: it writes to a temp file in the same directory, fsyncs it, then
+// renames it over path. The parent directory is fsynced too on Unix so the
+// rename itself survives a crash, mirroring tendermint's WriteFileAtomic.
+func writeFileAtomic(path string, data []byte, perm os.FileMode) error {
+	dir := filepath.Dir(path)
+	tmpPath := fmt.Sprintf("%s.tmp-%d", path, rand.Int63())
+
+	tmpFile, err := os.OpenFile(tmpPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, perm)
+	if err != nil {
+		return err
+	}
+
+	if _, err := tmpFile.Write(data); err != nil {
+		tmpFile.Close()
+		os.Remove(tmpPath)
+		return err
+	}
+
+	if err := tmpFile.Sync(); err != nil {
+		tmpFile.Close()
+		os.Remove(tmpPath)
+		return err
+	}
+
+	if err := tmpFile.Close(); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+
+	if err := os.Rename(tmpPath, path); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+
+	return syncDir(dir)
+}
+
+// syncDir fsyncs a directory so a preceding rename within it is durable.
+// Windows cannot fsync a directory handle, so there it does nothing.
+func syncDir(dir string) error {
+	if runtime.GOOS == "windows" {
+		return nil
+	}
+
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+
+	return d.Sync()
+}
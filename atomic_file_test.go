@@ -0,0 +1,74 @@
+package id_generator
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWriteFileAtomicCreatesFile(t *testing.T) {
+	dir, err := os.MkdirTemp("", "id_generator_atomic")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "out.txt")
+
+	if err := writeFileAtomic(path, []byte("hello"), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read written file: %v", err)
+	}
+	if string(content) != "hello" {
+		t.Errorf("Expected content %q, got %q", "hello", string(content))
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("Failed to read dir: %v", err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("Expected no leftover temp files, found %d entries: %v", len(entries), entries)
+	}
+}
+
+func TestWriteFileAtomicCleansUpTempFileOnRenameFailure(t *testing.T) {
+	dir, err := os.MkdirTemp("", "id_generator_atomic")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	// path is a directory, so the rename of tmpPath over it must fail.
+	path := filepath.Join(dir, "out")
+	if err := os.Mkdir(path, 0755); err != nil {
+		t.Fatalf("Failed to create blocking directory: %v", err)
+	}
+
+	err = writeFileAtomic(path, []byte("hello"), 0644)
+	if err == nil {
+		t.Fatalf("Expected writeFileAtomic to fail when path is a directory")
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("Failed to read dir: %v", err)
+	}
+	for _, entry := range entries {
+		if entry.Name() != "out" {
+			t.Errorf("Expected temp file to be cleaned up, found leftover %q", entry.Name())
+		}
+	}
+}
+
+func TestWriteFileAtomicFailsOnMissingDir(t *testing.T) {
+	path := filepath.Join(os.TempDir(), "id_generator_atomic_missing_dir", "out.txt")
+
+	if err := writeFileAtomic(path, []byte("hello"), 0644); err == nil {
+		t.Fatalf("Expected writeFileAtomic to fail when the parent directory does not exist")
+	}
+}
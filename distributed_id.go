@@ -0,0 +1,216 @@
+package id_generator
+
+import (
+	"crypto/md5"
+	cryptorand "crypto/rand"
+	"fmt"
+	mathrand "math/rand"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// encodeAlphabet is the lowercase base32-hex alphabet used to render an Id
+// as text, matching the Mongo/xid convention.
+const encodeAlphabet = "0123456789abcdefghijklmnopqrstuv"
+
+// idLen is the byte length of an Id: 4 bytes of time, 3 of machine, 2 of
+// pid, 3 of counter.
+const idLen = 12
+
+// idStringLen is the length of an Id's base32-hex text form.
+const idStringLen = 20
+
+// Id is a 12-byte globally unique identifier, laid out as 4 bytes of
+// big-endian Unix seconds, 3 of machine id, 2 of pid and 3 of counter. Unlike the cyclic integer IDs IdGenerator
+// produces, two DistributedIdGenerators never collide without coordination.
+type Id [idLen]byte
+
+// String renders id as a 20-character lowercase base32-hex string.
+func (id Id) String() string {
+	return encodeBase32Hex(id[:])
+}
+
+// Bytes returns the raw 12 bytes backing id.
+func (id Id) Bytes() []byte {
+	return append([]byte{}, id[:]...)
+}
+
+// Time returns the Unix-second timestamp encoded in id.
+func (id Id) Time() time.Time {
+	seconds := int64(id[0])<<24 | int64(id[1])<<16 | int64(id[2])<<8 | int64(id[3])
+	return time.Unix(seconds, 0)
+}
+
+// Machine returns the 3-byte machine identifier encoded in id.
+func (id Id) Machine() [3]byte {
+	return [3]byte{id[4], id[5], id[6]}
+}
+
+// Pid returns the process ID encoded in id.
+func (id Id) Pid() uint16 {
+	return uint16(id[7])<<8 | uint16(id[8])
+}
+
+// Counter returns the 24-bit monotonic counter encoded in id.
+func (id Id) Counter() uint32 {
+	return uint32(id[9])<<16 | uint32(id[10])<<8 | uint32(id[11])
+}
+
+// FromString parses the text form produced by Id.String.
+func FromString(s string) (Id, error) {
+	if len(s) != idStringLen {
+		return Id{}, fmt.Errorf("id_generator: invalid id length %d, want %d", len(s), idStringLen)
+	}
+
+	data, err := decodeBase32Hex(s)
+	if err != nil {
+		return Id{}, err
+	}
+
+	var id Id
+	copy(id[:], data)
+	return id, nil
+}
+
+// DistributedIdGenerator produces Ids that are unique across processes and
+// machines without any shared state, using the xid recipe: a Unix-second
+// timestamp, a machine identifier, the process PID, and a counter seeded
+// randomly at startup.
+type DistributedIdGenerator struct {
+	machineId [3]byte
+	pid       uint16
+	counter   uint32 // atomically incremented, masked to 24 bits
+
+	mu      sync.Mutex
+	usedMap map[Id]bool
+}
+
+// NewDistributedIdGenerator creates a DistributedIdGenerator.
+func NewDistributedIdGenerator(options ...func(*DistributedIdGenerator)) *DistributedIdGenerator {
+	g := &DistributedIdGenerator{
+		machineId: readMachineId(),
+		pid:       uint16(os.Getpid()),
+		counter:   mathrand.Uint32() & 0xffffff,
+		usedMap:   make(map[Id]bool),
+	}
+
+	for _, option := range options {
+		option(g)
+	}
+
+	return g
+}
+
+// GetId generates and returns a new globally unique Id.
+func (g *DistributedIdGenerator) GetId() Id {
+	var id Id
+
+	now := time.Now().Unix()
+	id[0] = byte(now >> 24)
+	id[1] = byte(now >> 16)
+	id[2] = byte(now >> 8)
+	id[3] = byte(now)
+
+	id[4], id[5], id[6] = g.machineId[0], g.machineId[1], g.machineId[2]
+
+	id[7] = byte(g.pid >> 8)
+	id[8] = byte(g.pid)
+
+	counter := atomic.AddUint32(&g.counter, 1) & 0xffffff
+	id[9] = byte(counter >> 16)
+	id[10] = byte(counter >> 8)
+	id[11] = byte(counter)
+
+	return id
+}
+
+// MarkIdAsUsed marks id as used, the distributed-mode equivalent of
+// IdGenerator.MarkIdAsUsed. Since Ids are already collision-free this only
+// records id in an internal set; no method of DistributedIdGenerator reads
+// that set back.
+func (g *DistributedIdGenerator) MarkIdAsUsed(id Id) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.usedMap[id] = true
+}
+
+// readMachineId hashes the local hostname down to 3 bytes, falling back to
+// a random value if the hostname can't be read.
+func readMachineId() [3]byte {
+	var mid [3]byte
+
+	hostname, err := os.Hostname()
+	if err != nil || hostname == "" {
+		if _, err := cryptorand.Read(mid[:]); err != nil {
+			mathrand.Read(mid[:])
+		}
+		return mid
+	}
+
+	sum := md5.Sum([]byte(hostname))
+	copy(mid[:], sum[:3])
+	return mid
+}
+
+// encodeBase32Hex renders src as a lowercase base32-hex string, packing its
+// bits most-significant-first and zero-padding the final partial group.
+func encodeBase32Hex(src []byte) string {
+	bitsTotal := len(src) * 8
+	groups := (bitsTotal + 4) / 5
+
+	out := make([]byte, groups)
+	for i := 0; i < groups; i++ {
+		var v byte
+		for b := 0; b < 5; b++ {
+			bitIndex := i*5 + b
+			var bit byte
+			if bitIndex < bitsTotal {
+				byteIndex := bitIndex / 8
+				bitInByte := 7 - bitIndex%8
+				bit = (src[byteIndex] >> bitInByte) & 1
+			}
+			v = v<<1 | bit
+		}
+		out[i] = encodeAlphabet[v]
+	}
+
+	return string(out)
+}
+
+// decodeBase32Hex is the inverse of encodeBase32Hex.
+func decodeBase32Hex(s string) ([]byte, error) {
+	byteLen := len(s) * 5 / 8
+	out := make([]byte, byteLen)
+
+	for i := 0; i < len(s); i++ {
+		v := indexAlphabet(s[i])
+		if v < 0 {
+			return nil, fmt.Errorf("id_generator: invalid id character %q", s[i])
+		}
+
+		for b := 0; b < 5; b++ {
+			bitIndex := i*5 + b
+			if bitIndex >= byteLen*8 {
+				break
+			}
+			bit := byte(v>>(4-b)) & 1
+			byteIndex := bitIndex / 8
+			bitInByte := 7 - bitIndex%8
+			out[byteIndex] |= bit << bitInByte
+		}
+	}
+
+	return out, nil
+}
+
+func indexAlphabet(c byte) int {
+	for i := 0; i < len(encodeAlphabet); i++ {
+		if encodeAlphabet[i] == c {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,53 @@
+package id_generator
+
+import "testing"
+
+func TestDistributedIdGeneratorUniqueness(t *testing.T) {
+	generator := NewDistributedIdGenerator()
+
+	seen := make(map[Id]bool)
+	for i := 0; i < 10000; i++ {
+		id := generator.GetId()
+		if seen[id] {
+			t.Fatalf("Generated duplicate id %s at iteration %d", id, i)
+		}
+		seen[id] = true
+	}
+}
+
+func TestDistributedIdStringRoundTrip(t *testing.T) {
+	generator := NewDistributedIdGenerator()
+	id := generator.GetId()
+
+	s := id.String()
+	if len(s) != idStringLen {
+		t.Fatalf("Expected string length %d, got %d", idStringLen, len(s))
+	}
+
+	parsed, err := FromString(s)
+	if err != nil {
+		t.Fatalf("Failed to parse id string %q: %v", s, err)
+	}
+
+	if parsed != id {
+		t.Errorf("Expected round-tripped id %v, got %v", id, parsed)
+	}
+}
+
+func TestFromStringRejectsInvalidLength(t *testing.T) {
+	if _, err := FromString("tooshort"); err == nil {
+		t.Errorf("Expected error for a string shorter than %d characters", idStringLen)
+	}
+}
+
+func TestFromStringRejectsInvalidCharacters(t *testing.T) {
+	// "w" is outside encodeAlphabet, which only goes up to "v".
+	invalid := "wwwwwwwwwwwwwwwwwwww"
+	if len(invalid) != idStringLen {
+		t.Fatalf("Test fixture length %d does not match idStringLen %d", len(invalid), idStringLen)
+	}
+
+	if _, err := FromString(invalid); err == nil {
+		t.Errorf("Expected error for a string containing characters outside encodeAlphabet")
+	}
+}
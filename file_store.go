@@ -0,0 +1,82 @@
+package id_generator
+
+import (
+	"bytes"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// FileStore is the original newline-delimited text file backend. It keeps
+// no persistent record of used IDs between calls; callers are expected to
+// filter used IDs out before SaveCycle, exactly as SyncIdsToFile does.
+type FileStore struct {
+	filename string
+}
+
+// NewFileStore creates a FileStore backed by the given file.
+func NewFileStore(filename string) *FileStore {
+	return &FileStore{filename: filename}
+}
+
+// LoadCycle reads the whole file and returns its IDs. startKey is ignored
+// since the text file always holds exactly one cycle's worth of IDs. A
+// missing or empty file is not an error; it simply yields no IDs.
+func (s *FileStore) LoadCycle(startKey int64) ([]int, error) {
+	fileInfo, err := os.Stat(s.filename)
+	if os.IsNotExist(err) || (err == nil && fileInfo.Size() == 0) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := os.ReadFile(s.filename)
+	if err != nil {
+		return nil, err
+	}
+
+	lines := bytes.Split(bytes.TrimRight(content, "\n"), []byte("\n"))
+	ids := make([]int, 0, len(lines))
+	for _, line := range lines {
+		if len(line) == 0 {
+			continue
+		}
+		id, err := strconv.Atoi(string(line))
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+// SaveCycle atomically writes ids to the file, one per line, so a crash
+// mid-write can never leave a truncated cycle behind. startKey is ignored
+// for the same reason it is ignored by LoadCycle.
+func (s *FileStore) SaveCycle(startKey int64, ids []int) error {
+	idsStr := make([]string, len(ids))
+	for i, id := range ids {
+		idsStr[i] = strconv.Itoa(id)
+	}
+
+	return writeFileAtomic(s.filename, []byte(strings.Join(idsStr, "\n")), 0644)
+}
+
+// MarkUsed is a no-op: the text file format has no per-ID used marker, so
+// "used" state is tracked in memory by IdGenerator and applied at the next
+// SaveCycle.
+func (s *FileStore) MarkUsed(id int) error {
+	return nil
+}
+
+// MarkUsedBatch is a no-op for the same reason MarkUsed is.
+func (s *FileStore) MarkUsedBatch(ids []int) error {
+	return nil
+}
+
+// PurgeUsed is a no-op for the same reason MarkUsed is.
+func (s *FileStore) PurgeUsed() ([]int, error) {
+	return nil, nil
+}
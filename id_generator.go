@@ -1,11 +1,8 @@
 package id_generator
 
 import (
-	"bytes"
+	"fmt"
 	"math/rand"
-	"os"
-	"strconv"
-	"strings"
 	"sync"
 	"time"
 )
@@ -25,6 +22,8 @@ type IdGenerator struct {
 	idMap         map[int64]int // Map storing corresponding IDs
 	usedIdMap     map[int]bool  // Map tracking used ID
 	filename      string        // Filename for ID storage
+	store         Store         // Persistence backend; defaults to a FileStore over filename
+	cycleStartKey int64         // Start key of the currently loaded cycle
 	cycleDuration time.Duration // Duration of each cycle
 	unitDuration  time.Duration // Duration of each unit
 	idMapLength   int           // Length of idMap
@@ -32,6 +31,8 @@ type IdGenerator struct {
 	minId         int           // Minimum ID value
 	maxId         int           // Maximum ID value
 	mu            sync.Mutex    // Mutex for synchronization
+	events        chan Event    // Lifecycle events published by Run
+	running       bool          // Whether Run's background goroutine is active
 }
 
 // NewIdGenerator creates a new instance of IdGenerator with optional cycle duration, unit duration, and filename
@@ -52,6 +53,10 @@ func NewIdGenerator(options ...func(*IdGenerator)) *IdGenerator {
 
 	g.idMapLength = int(g.cycleDuration / g.unitDuration)
 
+	if g.store == nil {
+		g.store = NewFileStore(g.filename)
+	}
+
 	return g
 }
 
@@ -76,6 +81,14 @@ func WithFilename(filename string) func(*IdGenerator) {
 	}
 }
 
+// WithStore overrides the persistence backend. When not given, the
+// generator persists to filename via a FileStore.
+func WithStore(store Store) func(*IdGenerator) {
+	return func(g *IdGenerator) {
+		g.store = store
+	}
+}
+
 func WithIdRange(minId, maxId int) func(*IdGenerator) {
 	return func(g *IdGenerator) {
 		g.minId = minId
@@ -89,39 +102,70 @@ func WithIdCount(count int) func(*IdGenerator) {
 	}
 }
 
-// Initialize loads Ids from file or generates new Ids and saves them to a file
+// Initialize loads Ids from the store or generates new Ids and saves them
 func (g *IdGenerator) Initialize() error {
-	if fileInfo, err := os.Stat(g.filename); os.IsNotExist(err) || fileInfo.Size() == 0 {
-		// IdFile does not exist, generate new Ids and save them to the file
+	startKey := g.getCycleStartTime(0).UnixNano() / int64(g.unitDuration)
+
+	ids, err := g.store.LoadCycle(startKey)
+	if err != nil {
+		return err
+	}
+
+	if len(ids) == 0 {
+		// Nothing persisted yet for this cycle, generate new Ids and save them
 		g.GenerateRandIds()
+
+		g.mu.Lock()
+		g.cycleStartKey = startKey
+		g.mu.Unlock()
+
 		return g.SaveIdsToFile()
 	}
 
-	// IdFile exists, load Ids from the file
-	return g.LoadIds(g.getCycleStartTime(0))
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.cycleStartKey = startKey
+	g.setCycleIds(startKey, ids)
+
+	return nil
 }
 
-// LoadIds loads corresponding Ids into the in-memory map
+// LoadIds loads the cycle starting at startTime into the in-memory map
 func (g *IdGenerator) LoadIds(startTime time.Time) error {
-	g.ids = make([]int, 0)
-	g.idMap = make(map[int64]int)
+	startKey := startTime.UnixNano() / int64(g.unitDuration)
 
-	content, err := os.ReadFile(g.filename)
+	ids, err := g.store.LoadCycle(startKey)
 	if err != nil {
 		return err
 	}
 
-	idsStr := bytes.Split(content, []byte("\n"))
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.cycleStartKey = startKey
+	g.setCycleIds(startKey, ids)
 
-	startTimeNum := startTime.UnixNano() / int64(g.unitDuration)
-	for i := 0; i < g.idMapLength; i++ {
-		key := startTimeNum + int64(i)
-		id, _ := strconv.Atoi(string(idsStr[i]))
-		g.idMap[key] = id
-		g.ids = append(g.ids, id)
+	return nil
+}
+
+// setCycleIds windows ids down to at most idMapLength entries (one per unit
+// of time in the cycle) and rebuilds idMap from that window. The caller must
+// hold g.mu. A store is free to return more IDs than a single cycle spans
+// (e.g. a FileStore holding all idCount generated IDs, far more than
+// idMapLength units); without this bound idMap would key entries far outside the
+// current cycle's time window.
+func (g *IdGenerator) setCycleIds(startKey int64, ids []int) {
+	length := len(ids)
+	if length > g.idMapLength {
+		length = g.idMapLength
 	}
+	g.ids = ids[:length]
 
-	return nil
+	g.idMap = make(map[int64]int, length)
+	for i, id := range g.ids {
+		g.idMap[startKey+int64(i)] = id
+	}
 }
 
 // GenerateRandIds generates randomly shuffled numeric Ids within the specified range
@@ -132,10 +176,10 @@ func (g *IdGenerator) GenerateRandIds() {
 		g.minId, g.maxId = g.maxId, g.minId
 	}
 
-	g.ids = make([]int, count)
+	ids := make([]int, count)
 
 	for i := g.minId; i < g.minId+count; i++ {
-		g.ids[i-g.minId] = i
+		ids[i-g.minId] = i
 	}
 
 	rand.Seed(time.Now().UnixNano())
@@ -143,29 +187,17 @@ func (g *IdGenerator) GenerateRandIds() {
 	// Fisher-Yates shuffle algorithm
 	for i := count - 1; i > 0; i-- {
 		j := rand.Intn(i + 1)
-		g.ids[i], g.ids[j] = g.ids[j], g.ids[i]
+		ids[i], ids[j] = ids[j], ids[i]
 	}
+
+	g.mu.Lock()
+	g.ids = ids
+	g.mu.Unlock()
 }
 
-// SaveIdsToFile saves Ids to a file
+// SaveIdsToFile saves Ids to the store
 func (g *IdGenerator) SaveIdsToFile() error {
-	file, err := os.Create(g.filename)
-	if err != nil {
-		return err
-	}
-	defer file.Close()
-
-	idsStr := make([]string, len(g.ids))
-	for i, id := range g.ids {
-		idsStr[i] = strconv.Itoa(id)
-	}
-
-	_, err = file.WriteString(strings.Join(idsStr, "\n"))
-	if err != nil {
-		return err
-	}
-
-	return nil
+	return g.store.SaveCycle(g.cycleStartKey, g.ids)
 }
 
 // GetId retrieves the Id corresponding to the specified seconds
@@ -192,28 +224,26 @@ func (g *IdGenerator) MarkIdAsUsed(id int) {
 	}
 
 	g.usedIdMap[id] = true
+	_ = g.store.MarkUsed(id)
 }
 
-// SyncIdsToFile synchronizes Id data to the file
+// SyncIdsToFile synchronizes the unused Id data to the store
 func (g *IdGenerator) SyncIdsToFile() error {
 	g.mu.Lock()
 	defer g.mu.Unlock()
 
-	file, err := os.Create(g.filename)
-	if err != nil {
-		return err
-	}
-	defer file.Close()
-
-	idsStr := make([]string, 0)
+	unused := make([]int, 0, len(g.ids))
 	for _, id := range g.ids {
 		if !g.usedIdMap[id] {
-			idsStr = append(idsStr, strconv.Itoa(id))
+			unused = append(unused, id)
 		}
 	}
 
-	_, err = file.WriteString(strings.Join(idsStr, "\n"))
-	if err != nil {
+	if err := g.store.SaveCycle(g.cycleStartKey, unused); err != nil {
+		return err
+	}
+
+	if _, err := g.store.PurgeUsed(); err != nil {
 		return err
 	}
 
@@ -222,6 +252,59 @@ func (g *IdGenerator) SyncIdsToFile() error {
 	return nil
 }
 
+// Reserve atomically pulls n unused IDs from the current cycle and marks
+// them used in one lock acquisition, for batch consumers that would
+// otherwise loop over GetId/MarkIdAsUsed. It is all-or-nothing: if fewer
+// than n IDs are available, none of them are marked used.
+func (g *IdGenerator) Reserve(n int) ([]int, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	available := make([]int, 0, n)
+	for _, id := range g.ids {
+		if len(available) == n {
+			break
+		}
+		if !g.usedIdMap[id] {
+			available = append(available, id)
+		}
+	}
+
+	if len(available) < n {
+		return nil, fmt.Errorf("id_generator: insufficient unused ids: want %d, got %d", n, len(available))
+	}
+
+	for _, id := range available {
+		g.usedIdMap[id] = true
+	}
+	_ = g.store.MarkUsedBatch(available)
+
+	return available, nil
+}
+
+// CycleStartKey returns the start key of the currently loaded cycle.
+func (g *IdGenerator) CycleStartKey() int64 {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	return g.cycleStartKey
+}
+
+// UnusedCount returns how many IDs in the current cycle have not been used.
+func (g *IdGenerator) UnusedCount() int {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	count := 0
+	for _, id := range g.ids {
+		if !g.usedIdMap[id] {
+			count++
+		}
+	}
+
+	return count
+}
+
 func (g *IdGenerator) getCycleStartTime(offset int) time.Time {
 	now := time.Now()
 	cycleOffset := time.Duration(offset) * g.cycleDuration
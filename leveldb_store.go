@@ -0,0 +1,151 @@
+package id_generator
+
+import (
+	"encoding/binary"
+	"strconv"
+
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/opt"
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+// LevelDBStore persists the ID pool in a LevelDB database, keyed by
+// (cycleStart, unit) so a single ID can be looked up or marked used without
+// loading the full cycle into memory.
+type LevelDBStore struct {
+	db *leveldb.DB
+}
+
+// NewLevelDBStore opens (creating if necessary) a LevelDB database at path.
+func NewLevelDBStore(path string) (*LevelDBStore, error) {
+	db, err := leveldb.OpenFile(path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &LevelDBStore{db: db}, nil
+}
+
+// Close releases the underlying database handle.
+func (s *LevelDBStore) Close() error {
+	return s.db.Close()
+}
+
+// cycleKey builds the "<cycleStart><unit>" key a cycle's IDs are stored
+// under, one entry per unit offset within the cycle. Both halves are
+// fixed-width big-endian so LevelDB's lexicographic key order matches
+// numeric unit order; a varint suffix would not (e.g. unit 127 encodes to a
+// byte string that sorts after unit 128's).
+func cycleKey(cycleStart int64, unit int) []byte {
+	key := make([]byte, 12)
+	binary.BigEndian.PutUint64(key[:8], uint64(cycleStart))
+	binary.BigEndian.PutUint32(key[8:], uint32(unit))
+	return key
+}
+
+// usedKey builds the key a used-ID marker is stored under.
+func usedKey(id int) []byte {
+	return []byte("used:" + strconv.Itoa(id))
+}
+
+// LoadCycle scans the (cycleStart, unit) range for startKey and returns the
+// IDs in unit order.
+func (s *LevelDBStore) LoadCycle(startKey int64) ([]int, error) {
+	prefix := make([]byte, 8)
+	binary.BigEndian.PutUint64(prefix, uint64(startKey))
+
+	iter := s.db.NewIterator(util.BytesPrefix(prefix), nil)
+	defer iter.Release()
+
+	var ids []int
+	for iter.Next() {
+		value := iter.Value()
+		id, err := strconv.Atoi(string(value))
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, iter.Error()
+}
+
+// SaveCycle writes every ID in ids under (startKey, i) as a single crash-safe
+// batch. Any keys a previous, longer SaveCycle left behind under startKey
+// are deleted first so a shorter cycle (e.g. after SyncIdsToFile drops used
+// ids) doesn't leave stale entries for LoadCycle to resurrect.
+func (s *LevelDBStore) SaveCycle(startKey int64, ids []int) error {
+	prefix := make([]byte, 8)
+	binary.BigEndian.PutUint64(prefix, uint64(startKey))
+
+	batch := new(leveldb.Batch)
+
+	iter := s.db.NewIterator(util.BytesPrefix(prefix), nil)
+	for iter.Next() {
+		batch.Delete(append([]byte{}, iter.Key()...))
+	}
+	if err := iter.Error(); err != nil {
+		iter.Release()
+		return err
+	}
+	iter.Release()
+
+	for i, id := range ids {
+		batch.Put(cycleKey(startKey, i), []byte(strconv.Itoa(id)))
+	}
+
+	return s.db.Write(batch, &opt.WriteOptions{Sync: true})
+}
+
+// MarkUsed records id as used via a single-entry write batch so the marker
+// is durable even if the process dies immediately afterward.
+func (s *LevelDBStore) MarkUsed(id int) error {
+	return s.MarkUsedBatch([]int{id})
+}
+
+// MarkUsedBatch records every id in ids as used in a single write batch, so
+// callers like Reserve that mark many ids at once pay for one fsync instead
+// of one per id.
+func (s *LevelDBStore) MarkUsedBatch(ids []int) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	batch := new(leveldb.Batch)
+	for _, id := range ids {
+		batch.Put(usedKey(id), []byte{1})
+	}
+
+	return s.db.Write(batch, &opt.WriteOptions{Sync: true})
+}
+
+// PurgeUsed collects every used-ID marker, deletes them in one batch, and
+// returns the IDs that were purged.
+func (s *LevelDBStore) PurgeUsed() ([]int, error) {
+	iter := s.db.NewIterator(util.BytesPrefix([]byte("used:")), nil)
+	defer iter.Release()
+
+	var ids []int
+	batch := new(leveldb.Batch)
+	for iter.Next() {
+		id, err := strconv.Atoi(string(iter.Key()[len("used:"):]))
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+		batch.Delete(append([]byte{}, iter.Key()...))
+	}
+	if err := iter.Error(); err != nil {
+		return nil, err
+	}
+
+	if batch.Len() == 0 {
+		return nil, nil
+	}
+
+	if err := s.db.Write(batch, &opt.WriteOptions{Sync: true}); err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
@@ -0,0 +1,80 @@
+package id_generator
+
+import (
+	"os"
+	"testing"
+)
+
+func TestLevelDBStoreSaveLoadRoundTrip(t *testing.T) {
+	dir, err := os.MkdirTemp("", "id_generator_leveldb")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	store, err := NewLevelDBStore(dir)
+	if err != nil {
+		t.Fatalf("Failed to open LevelDBStore: %v", err)
+	}
+	defer store.Close()
+
+	const startKey = int64(1000)
+
+	ids := make([]int, 200)
+	for i := range ids {
+		ids[i] = 100_000 + i
+	}
+
+	if err := store.SaveCycle(startKey, ids); err != nil {
+		t.Fatalf("Failed to save cycle: %v", err)
+	}
+
+	loaded, err := store.LoadCycle(startKey)
+	if err != nil {
+		t.Fatalf("Failed to load cycle: %v", err)
+	}
+
+	if len(loaded) != len(ids) {
+		t.Fatalf("Expected %d ids, got %d", len(ids), len(loaded))
+	}
+
+	for i, id := range ids {
+		if loaded[i] != id {
+			t.Errorf("Unit order diverged at index %d: expected %d, got %d", i, id, loaded[i])
+		}
+	}
+}
+
+func TestLevelDBStoreSaveCycleDropsStaleEntries(t *testing.T) {
+	dir, err := os.MkdirTemp("", "id_generator_leveldb")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	store, err := NewLevelDBStore(dir)
+	if err != nil {
+		t.Fatalf("Failed to open LevelDBStore: %v", err)
+	}
+	defer store.Close()
+
+	const startKey = int64(2000)
+
+	if err := store.SaveCycle(startKey, []int{1, 2, 3, 4, 5}); err != nil {
+		t.Fatalf("Failed to save initial cycle: %v", err)
+	}
+
+	// Simulate SyncIdsToFile persisting a shorter cycle after ids are used.
+	if err := store.SaveCycle(startKey, []int{1, 2}); err != nil {
+		t.Fatalf("Failed to save shorter cycle: %v", err)
+	}
+
+	loaded, err := store.LoadCycle(startKey)
+	if err != nil {
+		t.Fatalf("Failed to load cycle: %v", err)
+	}
+
+	if len(loaded) != 2 {
+		t.Fatalf("Expected stale entries to be dropped, got %d ids: %v", len(loaded), loaded)
+	}
+}
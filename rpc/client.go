@@ -0,0 +1,88 @@
+package rpc
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+
+	"github.com/tikbox/id_generator/rpc/idgenpb"
+)
+
+// Client is a thin wrapper around idgenpb.IdGeneratorClient that mirrors the
+// shape of the in-process IdGenerator API.
+type Client struct {
+	rpc idgenpb.IdGeneratorClient
+}
+
+// NewClient builds a Client over an existing gRPC connection.
+func NewClient(cc grpc.ClientConnInterface) *Client {
+	return &Client{rpc: idgenpb.NewIdGeneratorClient(cc)}
+}
+
+// GetId fetches the ID assigned to key.
+func (c *Client) GetId(ctx context.Context, key int64) (int, error) {
+	resp, err := c.rpc.GetId(ctx, &idgenpb.GetIdRequest{Key: key})
+	if err != nil {
+		return 0, err
+	}
+	return int(resp.Id), nil
+}
+
+// MarkIdAsUsed marks id as consumed.
+func (c *Client) MarkIdAsUsed(ctx context.Context, id int) error {
+	_, err := c.rpc.MarkUsed(ctx, &idgenpb.MarkUsedRequest{Id: int64(id)})
+	return err
+}
+
+// SyncNow forces an immediate sync of the current cycle to the store.
+func (c *Client) SyncNow(ctx context.Context) error {
+	_, err := c.rpc.SyncNow(ctx, &idgenpb.SyncNowRequest{})
+	return err
+}
+
+// Reserve leases n unused IDs from the current cycle in one call.
+func (c *Client) Reserve(ctx context.Context, n int) ([]int, error) {
+	resp, err := c.rpc.Reserve(ctx, &idgenpb.ReserveRequest{Count: int32(n)})
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]int, len(resp.Ids))
+	for i, id := range resp.Ids {
+		ids[i] = int(id)
+	}
+
+	return ids, nil
+}
+
+// Stats reports cycle start, remaining unused count, and sync lag.
+func (c *Client) Stats(ctx context.Context) (*idgenpb.StatsResponse, error) {
+	return c.rpc.Stats(ctx, &idgenpb.StatsRequest{})
+}
+
+// Watch streams cycle-rotation events until ctx is canceled or the stream
+// ends; each received event is sent on the returned channel.
+func (c *Client) Watch(ctx context.Context) (<-chan *idgenpb.CycleEvent, error) {
+	stream, err := c.rpc.Watch(ctx, &idgenpb.WatchRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	events := make(chan *idgenpb.CycleEvent)
+	go func() {
+		defer close(events)
+		for {
+			event, err := stream.Recv()
+			if err != nil {
+				return
+			}
+			select {
+			case events <- event:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return events, nil
+}
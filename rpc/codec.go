@@ -0,0 +1,42 @@
+package rpc
+
+import (
+	"encoding/json"
+
+	"google.golang.org/grpc"
+)
+
+// jsonCodec transports idgenpb messages as JSON instead of protobuf wire
+// format. The idgenpb message structs are hand-written plain Go structs,
+// not real protoc-gen-go output, so they don't implement proto.Message
+// (Reset/String/ProtoReflect); grpc-go's default codec requires that
+// interface and fails every call with "failed to marshal ... want
+// proto.Message" if left in place. ServerOption and DialOption below wire
+// this codec in so the RPCs this package defines actually work.
+type jsonCodec struct{}
+
+func (jsonCodec) Marshal(v interface{}) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (jsonCodec) Unmarshal(data []byte, v interface{}) error {
+	return json.Unmarshal(data, v)
+}
+
+func (jsonCodec) Name() string {
+	return "idgen-json"
+}
+
+// ServerOption returns the grpc.ServerOption that must be passed to
+// grpc.NewServer alongside RegisterIdGeneratorServer for this package's
+// RPCs to marshal correctly.
+func ServerOption() grpc.ServerOption {
+	return grpc.ForceServerCodec(jsonCodec{})
+}
+
+// DialOption returns the grpc.DialOption that must be passed to
+// grpc.Dial/grpc.DialContext (or used with NewClient's connection) so
+// calls use the same codec the server expects.
+func DialOption() grpc.DialOption {
+	return grpc.WithDefaultCallOptions(grpc.ForceCodec(jsonCodec{}))
+}
@@ -0,0 +1,56 @@
+// Package idgenpb mirrors the messages defined in idgen.proto. These are
+// plain Go structs hand-maintained to match the .proto until the protoc
+// toolchain is available in this environment; they deliberately do NOT
+// implement proto.Message and must be transported with the rpc package's
+// jsonCodec (see rpc.ServerOption/DialOption), not grpc-go's default codec.
+package idgenpb
+
+type GetIdRequest struct {
+	Key int64 `protobuf:"varint,1,opt,name=key,proto3" json:"key,omitempty"`
+}
+
+type GetIdResponse struct {
+	Id int64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+type MarkUsedRequest struct {
+	Id int64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+type MarkUsedResponse struct{}
+
+type SyncNowRequest struct{}
+
+type SyncNowResponse struct{}
+
+type ReserveRequest struct {
+	Count int32 `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+}
+
+type ReserveResponse struct {
+	Ids []int64 `protobuf:"varint,1,rep,packed,name=ids,proto3" json:"ids,omitempty"`
+}
+
+type StatsRequest struct{}
+
+type StatsResponse struct {
+	CycleStartKey  int64 `protobuf:"varint,1,opt,name=cycle_start_key,json=cycleStartKey,proto3" json:"cycle_start_key,omitempty"`
+	UnusedCount    int64 `protobuf:"varint,2,opt,name=unused_count,json=unusedCount,proto3" json:"unused_count,omitempty"`
+	SyncLagSeconds int64 `protobuf:"varint,3,opt,name=sync_lag_seconds,json=syncLagSeconds,proto3" json:"sync_lag_seconds,omitempty"`
+}
+
+type WatchRequest struct{}
+
+type CycleEvent_Kind int32
+
+const (
+	CycleEvent_CYCLE_ROTATED CycleEvent_Kind = 0
+	CycleEvent_SYNC_FAILED   CycleEvent_Kind = 1
+	CycleEvent_LOW_INVENTORY CycleEvent_Kind = 2
+)
+
+type CycleEvent struct {
+	Kind          CycleEvent_Kind `protobuf:"varint,1,opt,name=kind,proto3,enum=idgen.CycleEvent_Kind" json:"kind,omitempty"`
+	CycleStartKey int64           `protobuf:"varint,2,opt,name=cycle_start_key,json=cycleStartKey,proto3" json:"cycle_start_key,omitempty"`
+	Detail        string          `protobuf:"bytes,3,opt,name=detail,proto3" json:"detail,omitempty"`
+}
@@ -0,0 +1,260 @@
+// This file hand-maintains the client/server surface protoc-gen-go-grpc
+// would otherwise generate from idgen.proto's service definition; the
+// grpc.ClientConnInterface/ServiceDesc plumbing below is real and works
+// with any encoding.Codec, which is what lets the jsonCodec in
+// rpc.ServerOption/DialOption stand in for the missing protobuf messages.
+package idgenpb
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// IdGeneratorClient is the client API for the IdGenerator service.
+type IdGeneratorClient interface {
+	GetId(ctx context.Context, in *GetIdRequest, opts ...grpc.CallOption) (*GetIdResponse, error)
+	MarkUsed(ctx context.Context, in *MarkUsedRequest, opts ...grpc.CallOption) (*MarkUsedResponse, error)
+	SyncNow(ctx context.Context, in *SyncNowRequest, opts ...grpc.CallOption) (*SyncNowResponse, error)
+	Reserve(ctx context.Context, in *ReserveRequest, opts ...grpc.CallOption) (*ReserveResponse, error)
+	Stats(ctx context.Context, in *StatsRequest, opts ...grpc.CallOption) (*StatsResponse, error)
+	Watch(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (IdGenerator_WatchClient, error)
+}
+
+type idGeneratorClient struct {
+	cc grpc.ClientConnInterface
+}
+
+// NewIdGeneratorClient builds a client bound to an existing gRPC connection.
+func NewIdGeneratorClient(cc grpc.ClientConnInterface) IdGeneratorClient {
+	return &idGeneratorClient{cc}
+}
+
+func (c *idGeneratorClient) GetId(ctx context.Context, in *GetIdRequest, opts ...grpc.CallOption) (*GetIdResponse, error) {
+	out := new(GetIdResponse)
+	if err := c.cc.Invoke(ctx, "/idgen.IdGenerator/GetId", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *idGeneratorClient) MarkUsed(ctx context.Context, in *MarkUsedRequest, opts ...grpc.CallOption) (*MarkUsedResponse, error) {
+	out := new(MarkUsedResponse)
+	if err := c.cc.Invoke(ctx, "/idgen.IdGenerator/MarkUsed", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *idGeneratorClient) SyncNow(ctx context.Context, in *SyncNowRequest, opts ...grpc.CallOption) (*SyncNowResponse, error) {
+	out := new(SyncNowResponse)
+	if err := c.cc.Invoke(ctx, "/idgen.IdGenerator/SyncNow", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *idGeneratorClient) Reserve(ctx context.Context, in *ReserveRequest, opts ...grpc.CallOption) (*ReserveResponse, error) {
+	out := new(ReserveResponse)
+	if err := c.cc.Invoke(ctx, "/idgen.IdGenerator/Reserve", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *idGeneratorClient) Stats(ctx context.Context, in *StatsRequest, opts ...grpc.CallOption) (*StatsResponse, error) {
+	out := new(StatsResponse)
+	if err := c.cc.Invoke(ctx, "/idgen.IdGenerator/Stats", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *idGeneratorClient) Watch(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (IdGenerator_WatchClient, error) {
+	stream, err := c.cc.(interface {
+		NewStream(ctx context.Context, desc *grpc.StreamDesc, method string, opts ...grpc.CallOption) (grpc.ClientStream, error)
+	}).NewStream(ctx, &IdGenerator_ServiceDesc.Streams[0], "/idgen.IdGenerator/Watch", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &idGeneratorWatchClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// IdGenerator_WatchClient is the stream client returned by Watch.
+type IdGenerator_WatchClient interface {
+	Recv() (*CycleEvent, error)
+	grpc.ClientStream
+}
+
+type idGeneratorWatchClient struct {
+	grpc.ClientStream
+}
+
+func (x *idGeneratorWatchClient) Recv() (*CycleEvent, error) {
+	m := new(CycleEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// IdGeneratorServer is the server API for the IdGenerator service.
+type IdGeneratorServer interface {
+	GetId(context.Context, *GetIdRequest) (*GetIdResponse, error)
+	MarkUsed(context.Context, *MarkUsedRequest) (*MarkUsedResponse, error)
+	SyncNow(context.Context, *SyncNowRequest) (*SyncNowResponse, error)
+	Reserve(context.Context, *ReserveRequest) (*ReserveResponse, error)
+	Stats(context.Context, *StatsRequest) (*StatsResponse, error)
+	Watch(*WatchRequest, IdGenerator_WatchServer) error
+}
+
+// UnimplementedIdGeneratorServer can be embedded to satisfy forward
+// compatibility with new methods added to the service.
+type UnimplementedIdGeneratorServer struct{}
+
+func (UnimplementedIdGeneratorServer) GetId(context.Context, *GetIdRequest) (*GetIdResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetId not implemented")
+}
+func (UnimplementedIdGeneratorServer) MarkUsed(context.Context, *MarkUsedRequest) (*MarkUsedResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method MarkUsed not implemented")
+}
+func (UnimplementedIdGeneratorServer) SyncNow(context.Context, *SyncNowRequest) (*SyncNowResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SyncNow not implemented")
+}
+func (UnimplementedIdGeneratorServer) Reserve(context.Context, *ReserveRequest) (*ReserveResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Reserve not implemented")
+}
+func (UnimplementedIdGeneratorServer) Stats(context.Context, *StatsRequest) (*StatsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Stats not implemented")
+}
+func (UnimplementedIdGeneratorServer) Watch(*WatchRequest, IdGenerator_WatchServer) error {
+	return status.Error(codes.Unimplemented, "method Watch not implemented")
+}
+
+// IdGenerator_WatchServer is the stream server side of Watch.
+type IdGenerator_WatchServer interface {
+	Send(*CycleEvent) error
+	grpc.ServerStream
+}
+
+type idGeneratorWatchServer struct {
+	grpc.ServerStream
+}
+
+func (x *idGeneratorWatchServer) Send(m *CycleEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func RegisterIdGeneratorServer(s grpc.ServiceRegistrar, srv IdGeneratorServer) {
+	s.RegisterService(&IdGenerator_ServiceDesc, srv)
+}
+
+func handlerGetId(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetIdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IdGeneratorServer).GetId(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/idgen.IdGenerator/GetId"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IdGeneratorServer).GetId(ctx, req.(*GetIdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func handlerMarkUsed(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MarkUsedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IdGeneratorServer).MarkUsed(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/idgen.IdGenerator/MarkUsed"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IdGeneratorServer).MarkUsed(ctx, req.(*MarkUsedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func handlerSyncNow(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SyncNowRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IdGeneratorServer).SyncNow(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/idgen.IdGenerator/SyncNow"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IdGeneratorServer).SyncNow(ctx, req.(*SyncNowRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func handlerReserve(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReserveRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IdGeneratorServer).Reserve(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/idgen.IdGenerator/Reserve"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IdGeneratorServer).Reserve(ctx, req.(*ReserveRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func handlerStats(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IdGeneratorServer).Stats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/idgen.IdGenerator/Stats"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IdGeneratorServer).Stats(ctx, req.(*StatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func handlerWatch(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(IdGeneratorServer).Watch(m, &idGeneratorWatchServer{stream})
+}
+
+// IdGenerator_ServiceDesc is the grpc.ServiceDesc for the IdGenerator
+// service, used by RegisterIdGeneratorServer and NewIdGeneratorClient.
+var IdGenerator_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "idgen.IdGenerator",
+	HandlerType: (*IdGeneratorServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{MethodName: "GetId", Handler: handlerGetId},
+		{MethodName: "MarkUsed", Handler: handlerMarkUsed},
+		{MethodName: "SyncNow", Handler: handlerSyncNow},
+		{MethodName: "Reserve", Handler: handlerReserve},
+		{MethodName: "Stats", Handler: handlerStats},
+	},
+	Streams: []grpc.StreamDesc{
+		{StreamName: "Watch", Handler: handlerWatch, ServerStreams: true},
+	},
+	Metadata: "idgen.proto",
+}
@@ -0,0 +1,64 @@
+package rpc
+
+import (
+	"context"
+	"net"
+	"os"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/tikbox/id_generator"
+	"github.com/tikbox/id_generator/rpc/idgenpb"
+)
+
+// TestStatsRoundTrip proves the RPC surface actually marshals end to end
+// over the jsonCodec, rather than only type-checking against idgenpb.
+func TestStatsRoundTrip(t *testing.T) {
+	file, err := os.CreateTemp("", "id_list.txt")
+	if err != nil {
+		t.Fatalf("Failed to create temporary file: %v", err)
+	}
+	defer os.Remove(file.Name())
+
+	gen := id_generator.NewIdGenerator(id_generator.WithIdCount(10), id_generator.WithFilename(file.Name()))
+	if err := gen.Initialize(); err != nil {
+		t.Fatalf("Failed to initialize generator: %v", err)
+	}
+
+	lis := bufconn.Listen(1024 * 1024)
+	grpcServer := grpc.NewServer(ServerOption())
+	idgenpb.RegisterIdGeneratorServer(grpcServer, NewServer(gen))
+
+	go func() {
+		if err := grpcServer.Serve(lis); err != nil {
+			t.Logf("grpc server stopped: %v", err)
+		}
+	}()
+	defer grpcServer.Stop()
+
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		DialOption(),
+	)
+	if err != nil {
+		t.Fatalf("Failed to dial bufconn: %v", err)
+	}
+	defer conn.Close()
+
+	client := NewClient(conn)
+
+	stats, err := client.Stats(context.Background())
+	if err != nil {
+		t.Fatalf("Stats RPC failed: %v", err)
+	}
+
+	if stats.UnusedCount != 10 {
+		t.Errorf("Expected UnusedCount 10, got %d", stats.UnusedCount)
+	}
+}
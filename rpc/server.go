@@ -0,0 +1,174 @@
+// Package rpc exposes an IdGenerator over gRPC so multiple app instances can
+// share one authoritative ID pool instead of each maintaining its own file,
+// analogous to the remote-DB pattern in tendermint's db/remotedb.
+package rpc
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/tikbox/id_generator"
+	"github.com/tikbox/id_generator/rpc/idgenpb"
+)
+
+// Server wraps an *id_generator.IdGenerator and exposes it over gRPC. All
+// state changes are serialized through the generator's own mutex; Server
+// adds nothing beyond that except cycle-rotation fan-out to watchers.
+type Server struct {
+	idgenpb.UnimplementedIdGeneratorServer
+
+	gen *id_generator.IdGenerator
+
+	mu         sync.Mutex
+	watchers   map[chan *idgenpb.CycleEvent]struct{}
+	lastSyncAt time.Time
+}
+
+// NewServer creates a Server around an already-initialized generator and
+// starts forwarding its lifecycle events (published once Run is called) to
+// Watch subscribers.
+func NewServer(gen *id_generator.IdGenerator) *Server {
+	s := &Server{
+		gen:      gen,
+		watchers: make(map[chan *idgenpb.CycleEvent]struct{}),
+	}
+
+	go s.forwardEvents()
+
+	return s
+}
+
+// forwardEvents relays gen.Events() to Watch subscribers for the lifetime
+// of the server.
+func (s *Server) forwardEvents() {
+	for event := range s.gen.Events() {
+		kind := idgenpb.CycleEvent_CYCLE_ROTATED
+		if event.Kind == id_generator.CycleRotated {
+			// Run only reports a rotation after the closing cycle synced.
+			s.mu.Lock()
+			s.lastSyncAt = time.Now()
+			s.mu.Unlock()
+		}
+		switch event.Kind {
+		case id_generator.SyncFailed:
+			kind = idgenpb.CycleEvent_SYNC_FAILED
+		case id_generator.LowInventory:
+			kind = idgenpb.CycleEvent_LOW_INVENTORY
+		}
+
+		s.broadcast(&idgenpb.CycleEvent{
+			Kind:          kind,
+			CycleStartKey: event.CycleStartKey,
+			Detail:        event.Detail,
+		})
+	}
+}
+
+// GetId returns the ID assigned to req.Key, or 0 if none is available.
+func (s *Server) GetId(ctx context.Context, req *idgenpb.GetIdRequest) (*idgenpb.GetIdResponse, error) {
+	return &idgenpb.GetIdResponse{Id: int64(s.gen.GetId(req.Key))}, nil
+}
+
+// MarkUsed marks req.Id as consumed.
+func (s *Server) MarkUsed(ctx context.Context, req *idgenpb.MarkUsedRequest) (*idgenpb.MarkUsedResponse, error) {
+	s.gen.MarkIdAsUsed(int(req.Id))
+	return &idgenpb.MarkUsedResponse{}, nil
+}
+
+// SyncNow forces an immediate sync of the current cycle to the store and
+// notifies Watch subscribers that the cycle was synced.
+func (s *Server) SyncNow(ctx context.Context, req *idgenpb.SyncNowRequest) (*idgenpb.SyncNowResponse, error) {
+	if err := s.gen.SyncIdsToFile(); err != nil {
+		s.broadcast(&idgenpb.CycleEvent{
+			Kind:          idgenpb.CycleEvent_SYNC_FAILED,
+			CycleStartKey: s.gen.CycleStartKey(),
+			Detail:        err.Error(),
+		})
+		return nil, err
+	}
+
+	s.mu.Lock()
+	s.lastSyncAt = time.Now()
+	s.mu.Unlock()
+
+	s.broadcast(&idgenpb.CycleEvent{
+		Kind:          idgenpb.CycleEvent_CYCLE_ROTATED,
+		CycleStartKey: s.gen.CycleStartKey(),
+	})
+
+	return &idgenpb.SyncNowResponse{}, nil
+}
+
+// Reserve leases n unused IDs from the current cycle in one call.
+func (s *Server) Reserve(ctx context.Context, req *idgenpb.ReserveRequest) (*idgenpb.ReserveResponse, error) {
+	ids, err := s.gen.Reserve(int(req.Count))
+	if err != nil {
+		return nil, err
+	}
+
+	reserved := make([]int64, len(ids))
+	for i, id := range ids {
+		reserved[i] = int64(id)
+	}
+
+	return &idgenpb.ReserveResponse{Ids: reserved}, nil
+}
+
+// Stats reports cycle start, remaining unused count, and sync lag.
+func (s *Server) Stats(ctx context.Context, req *idgenpb.StatsRequest) (*idgenpb.StatsResponse, error) {
+	s.mu.Lock()
+	lastSyncAt := s.lastSyncAt
+	s.mu.Unlock()
+
+	var syncLag int64
+	if !lastSyncAt.IsZero() {
+		syncLag = int64(time.Since(lastSyncAt).Seconds())
+	}
+
+	return &idgenpb.StatsResponse{
+		CycleStartKey:  s.gen.CycleStartKey(),
+		UnusedCount:    int64(s.gen.UnusedCount()),
+		SyncLagSeconds: syncLag,
+	}, nil
+}
+
+// Watch streams cycle-rotation events to the caller until ctx is canceled.
+func (s *Server) Watch(req *idgenpb.WatchRequest, stream idgenpb.IdGenerator_WatchServer) error {
+	ch := make(chan *idgenpb.CycleEvent, 16)
+
+	s.mu.Lock()
+	s.watchers[ch] = struct{}{}
+	s.mu.Unlock()
+
+	defer func() {
+		s.mu.Lock()
+		delete(s.watchers, ch)
+		s.mu.Unlock()
+	}()
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		case event := <-ch:
+			if err := stream.Send(event); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// broadcast fans an event out to every subscribed watcher, dropping it for
+// any watcher whose channel is full rather than blocking the caller.
+func (s *Server) broadcast(event *idgenpb.CycleEvent) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for ch := range s.watchers {
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+}
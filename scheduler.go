@@ -0,0 +1,121 @@
+package id_generator
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// EventKind identifies the kind of lifecycle event emitted on the channel
+// returned by IdGenerator.Events.
+type EventKind int
+
+const (
+	// CycleRotated is emitted after a cycle boundary sync and load succeed.
+	CycleRotated EventKind = iota
+	// SyncFailed is emitted when a scheduled sync or load fails.
+	SyncFailed
+	// LowInventory is emitted when a freshly rotated cycle starts with
+	// fewer unused IDs than DefaultLowInventoryFraction of idCount.
+	LowInventory
+)
+
+// DefaultLowInventoryFraction is the fraction of idCount below which a
+// freshly rotated cycle is considered low on inventory.
+const DefaultLowInventoryFraction = 0.1
+
+// Event describes a single lifecycle occurrence from Run.
+type Event struct {
+	Kind          EventKind
+	CycleStartKey int64
+	Detail        string
+}
+
+// Events returns the channel Run publishes lifecycle events on. It is safe
+// to call before Run.
+func (g *IdGenerator) Events() <-chan Event {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.events == nil {
+		g.events = make(chan Event, 16)
+	}
+
+	return g.events
+}
+
+// Run starts a background goroutine that wakes up at each wall-clock cycle
+// boundary: it syncs the closing cycle, loads the next one, and emits
+// lifecycle events on the Events channel. It returns an error if already
+// running; otherwise it returns immediately and stops when ctx is canceled,
+// draining one final sync before exiting.
+func (g *IdGenerator) Run(ctx context.Context) error {
+	g.mu.Lock()
+	if g.running {
+		g.mu.Unlock()
+		return errors.New("id_generator: already running")
+	}
+	g.running = true
+	if g.events == nil {
+		g.events = make(chan Event, 16)
+	}
+	g.mu.Unlock()
+
+	nextBoundary := g.getCycleStartTime(1)
+
+	go func() {
+		defer func() {
+			g.mu.Lock()
+			g.running = false
+			g.mu.Unlock()
+		}()
+
+		for {
+			timer := time.NewTimer(time.Until(nextBoundary))
+
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				if err := g.SyncIdsToFile(); err != nil {
+					g.emit(Event{Kind: SyncFailed, CycleStartKey: g.CycleStartKey(), Detail: err.Error()})
+				}
+				return
+			case <-timer.C:
+				g.rotateCycle(nextBoundary)
+				nextBoundary = nextBoundary.Add(g.cycleDuration)
+			}
+		}
+	}()
+
+	return nil
+}
+
+// rotateCycle syncs the closing cycle, loads the cycle starting at next,
+// and emits the resulting lifecycle events. next is the scheduled boundary
+// time rather than time.Now(), so a delayed tick can never skip over the
+// cycle that should follow the one just synced.
+func (g *IdGenerator) rotateCycle(next time.Time) {
+	if err := g.SyncIdsToFile(); err != nil {
+		g.emit(Event{Kind: SyncFailed, CycleStartKey: g.CycleStartKey(), Detail: err.Error()})
+		return
+	}
+
+	if err := g.LoadIds(next); err != nil {
+		g.emit(Event{Kind: SyncFailed, CycleStartKey: g.CycleStartKey(), Detail: err.Error()})
+		return
+	}
+
+	g.emit(Event{Kind: CycleRotated, CycleStartKey: g.CycleStartKey()})
+
+	if float64(g.UnusedCount()) < float64(g.idCount)*DefaultLowInventoryFraction {
+		g.emit(Event{Kind: LowInventory, CycleStartKey: g.CycleStartKey()})
+	}
+}
+
+// emit publishes an event without blocking; a full Events channel drops it.
+func (g *IdGenerator) emit(event Event) {
+	select {
+	case g.events <- event:
+	default:
+	}
+}
@@ -0,0 +1,108 @@
+package id_generator
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestReserveAllOrNothing(t *testing.T) {
+	generator := NewIdGenerator(WithIdCount(5), WithIdRange(1, 5))
+	generator.GenerateRandIds()
+
+	if _, err := generator.Reserve(6); err == nil {
+		t.Fatalf("Expected Reserve to fail when asking for more ids than are available")
+	}
+
+	if got := generator.UnusedCount(); got != 5 {
+		t.Errorf("Expected a failed Reserve to leave all ids unused, got %d unused", got)
+	}
+
+	reserved, err := generator.Reserve(5)
+	if err != nil {
+		t.Fatalf("Failed to reserve all available ids: %v", err)
+	}
+	if len(reserved) != 5 {
+		t.Errorf("Expected 5 reserved ids, got %d", len(reserved))
+	}
+
+	if got := generator.UnusedCount(); got != 0 {
+		t.Errorf("Expected no unused ids left after reserving all of them, got %d", got)
+	}
+
+	if _, err := generator.Reserve(1); err == nil {
+		t.Errorf("Expected Reserve to fail once the pool is exhausted")
+	}
+}
+
+func TestRunRotatesCycleAndEmitsEvent(t *testing.T) {
+	file, err := os.CreateTemp("", "id_list.txt")
+	if err != nil {
+		t.Fatalf("Failed to create temporary file: %v", err)
+	}
+	defer os.Remove(file.Name())
+
+	generator := NewIdGenerator(
+		WithFilename(file.Name()),
+		WithIdCount(10),
+		WithCycleDuration(100*time.Millisecond),
+		WithUnitDuration(10*time.Millisecond),
+	)
+
+	if err := generator.Initialize(); err != nil {
+		t.Fatalf("Failed to initialize generator: %v", err)
+	}
+
+	events := generator.Events()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if err := generator.Run(ctx); err != nil {
+		t.Fatalf("Failed to start Run: %v", err)
+	}
+
+	firstCycle := generator.CycleStartKey()
+
+	select {
+	case event := <-events:
+		if event.Kind != CycleRotated {
+			t.Fatalf("Expected a CycleRotated event, got kind %v (detail %q)", event.Kind, event.Detail)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatalf("Timed out waiting for a cycle rotation event")
+	}
+
+	if generator.CycleStartKey() == firstCycle {
+		t.Errorf("Expected CycleStartKey to advance after a rotation")
+	}
+
+	cancel()
+}
+
+func TestRunRejectsConcurrentStart(t *testing.T) {
+	file, err := os.CreateTemp("", "id_list.txt")
+	if err != nil {
+		t.Fatalf("Failed to create temporary file: %v", err)
+	}
+	defer os.Remove(file.Name())
+
+	generator := NewIdGenerator(WithFilename(file.Name()), WithIdCount(10))
+	if err := generator.Initialize(); err != nil {
+		t.Fatalf("Failed to initialize generator: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if err := generator.Run(ctx); err != nil {
+		t.Fatalf("Failed to start Run: %v", err)
+	}
+
+	if err := generator.Run(ctx); err == nil {
+		t.Errorf("Expected a second Run call to fail while the first is still active")
+	}
+
+	cancel()
+}
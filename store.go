@@ -0,0 +1,28 @@
+package id_generator
+
+// Store persists the ID pool backing an IdGenerator. Implementations decide
+// how cycles are laid out on disk and how "used" markers are tracked; the
+// generator only ever talks to this interface so the on-disk format can be
+// swapped out with WithStore.
+type Store interface {
+	// LoadCycle returns the ordered IDs for the cycle starting at startKey.
+	// A nil, nil result means no data exists yet for that cycle.
+	LoadCycle(startKey int64) ([]int, error)
+
+	// SaveCycle persists the ordered IDs for the cycle starting at startKey.
+	SaveCycle(startKey int64, ids []int) error
+
+	// MarkUsed records that id has been handed out. LoadCycle does not
+	// consult these markers; a used id leaves the stored cycle when
+	// IdGenerator.SyncIdsToFile saves only the unused ids.
+	MarkUsed(id int) error
+
+	// MarkUsedBatch is the bulk form of MarkUsed, for callers like Reserve
+	// that hand out many ids in one lock acquisition. Implementations
+	// should commit it as a single write rather than one per id.
+	MarkUsedBatch(ids []int) error
+
+	// PurgeUsed flushes and clears whatever used-ID markers the store has
+	// accumulated, returning the IDs that were purged.
+	PurgeUsed() ([]int, error)
+}